@@ -0,0 +1,154 @@
+// Package client drives load against the REST and gRPC endpoints served
+// by package server, and reports latency statistics via package stats.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/server"
+	"example.com/benchmark_proj/stats"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// MeasurePayloadSize prints the wire size of data under both JSON and
+// Protobuf encoding.
+func MeasurePayloadSize(data *benchmark.Data) {
+	jsonData, _ := json.Marshal(data)
+	fmt.Printf("JSON Payload Size: %d bytes\n", len(jsonData))
+
+	protoData, _ := proto.Marshal(data)
+	fmt.Printf("Protobuf Payload Size: %d bytes\n", len(protoData))
+}
+
+// BenchmarkRESTAPI issues iterations sequential REST calls and prints
+// total/average time.
+func BenchmarkRESTAPI(url string, data *server.Data, iterations int) {
+	jsonData, _ := json.Marshal(data)
+
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+		if err != nil {
+			log.Fatalf("REST API call failed: %v", err)
+		}
+		resp.Body.Close()
+	}
+	duration := time.Since(start)
+	fmt.Printf("REST API Total Time for %d iterations: %v\n", iterations, duration)
+	fmt.Printf("REST API Average Time per Call: %v\n", duration/time.Duration(iterations))
+}
+
+// BenchmarkRPCAPI issues iterations sequential gRPC calls and prints
+// total/average time.
+func BenchmarkRPCAPI(client benchmark.APIClient, data *benchmark.Data, iterations int) {
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		_, err := client.SendData(context.Background(), data)
+		if err != nil {
+			log.Fatalf("gRPC API call failed: %v", err)
+		}
+	}
+	duration := time.Since(start)
+	fmt.Printf("RPC API Total Time for %d iterations: %v\n", iterations, duration)
+	fmt.Printf("RPC API Average Time per Call: %v\n", duration/time.Duration(iterations))
+}
+
+// BenchmarkConcurrencyRPC runs concurrency closed-loop callers against
+// the gRPC API and returns the merged latency snapshot.
+func BenchmarkConcurrencyRPC(client benchmark.APIClient, data *benchmark.Data, concurrency int, iterations int) stats.Snapshot {
+	var wg sync.WaitGroup
+	perGoroutine := make([]*stats.Histogram, concurrency)
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		h := stats.NewHistogram()
+		perGoroutine[i] = h
+		wg.Add(1)
+		go func(h *stats.Histogram) {
+			defer wg.Done()
+			for j := 0; j < iterations/concurrency; j++ {
+				callStart := time.Now()
+				_, err := client.SendData(context.Background(), data)
+				if err != nil {
+					log.Printf("gRPC API call failed: %v", err)
+					h.RecordError()
+					continue
+				}
+				h.Record(time.Since(callStart))
+			}
+		}(h)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	merged := stats.NewHistogram()
+	for _, h := range perGoroutine {
+		merged.Merge(h)
+	}
+
+	snapshot := merged.Snapshot(elapsed)
+	fmt.Println("gRPC API - Concurrency stats:")
+	fmt.Println(snapshot)
+	return snapshot
+}
+
+// BenchmarkConcurrencyREST runs concurrency closed-loop callers against
+// the REST API and returns the merged latency snapshot.
+func BenchmarkConcurrencyREST(url string, data *server.Data, concurrency int, iterations int) stats.Snapshot {
+	var wg sync.WaitGroup
+	perGoroutine := make([]*stats.Histogram, concurrency)
+
+	start := time.Now()
+	for i := 0; i < concurrency; i++ {
+		h := stats.NewHistogram()
+		perGoroutine[i] = h
+		wg.Add(1)
+		go func(h *stats.Histogram) {
+			defer wg.Done()
+			for j := 0; j < iterations/concurrency; j++ {
+				callStart := time.Now()
+				jsonData, _ := json.Marshal(data)
+				resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+				if err != nil {
+					log.Printf("REST API call failed: %v", err)
+					h.RecordError()
+					continue
+				}
+				resp.Body.Close()
+				h.Record(time.Since(callStart))
+			}
+		}(h)
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	merged := stats.NewHistogram()
+	for _, h := range perGoroutine {
+		merged.Merge(h)
+	}
+
+	snapshot := merged.Snapshot(elapsed)
+	fmt.Println("REST API - Concurrency stats:")
+	fmt.Println(snapshot)
+	return snapshot
+}
+
+// Dial opens a gRPC connection to addr using the same options this
+// module has always used for its client benchmarks.
+func Dial(addr string) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("dial %s: %w", addr, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/loadgen"
+	"example.com/benchmark_proj/stats"
+)
+
+// LiveHistogram wraps a stats.Histogram with a mutex so a caller can
+// snapshot in-progress load while goroutines are still recording into
+// it. stats.Histogram itself is intentionally not safe for concurrent
+// use; this wrapper trades a little throughput for the ability to
+// report periodic progress, which is what the driver/worker control
+// plane needs and the one-shot Benchmark* functions in this package
+// don't.
+type LiveHistogram struct {
+	mu sync.Mutex
+	h  *stats.Histogram
+}
+
+// NewLiveHistogram returns an empty LiveHistogram.
+func NewLiveHistogram() *LiveHistogram {
+	return &LiveHistogram{h: stats.NewHistogram()}
+}
+
+// Record implements recorder.
+func (l *LiveHistogram) Record(d time.Duration) {
+	l.mu.Lock()
+	l.h.Record(d)
+	l.mu.Unlock()
+}
+
+// RecordError implements recorder.
+func (l *LiveHistogram) RecordError() {
+	l.mu.Lock()
+	l.h.RecordError()
+	l.mu.Unlock()
+}
+
+// Snapshot returns the histogram's current contents as of the call,
+// safe to invoke concurrently with Record/RecordError.
+func (l *LiveHistogram) Snapshot(elapsed time.Duration) stats.Snapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.h.Snapshot(elapsed)
+}
+
+// RunClosedLoopRPC drives concurrency closed-loop callers against client
+// until ctx is done, recording every call into hist. Unlike
+// BenchmarkConcurrencyRPC, it runs for as long as ctx allows rather than
+// a fixed iteration count, and records into hist as it goes so a caller
+// can poll hist.Snapshot for live progress.
+func RunClosedLoopRPC(ctx context.Context, client benchmark.APIClient, data *benchmark.Data, concurrency int, hist *LiveHistogram) {
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				callStart := time.Now()
+				_, err := client.SendData(ctx, data)
+				if err != nil {
+					hist.RecordError()
+					continue
+				}
+				hist.Record(time.Since(callStart))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// RunOpenLoopRPC is the live-reporting counterpart to
+// BenchmarkOpenLoopRPC: it drives load per cfg until ctx is done or
+// cfg.Duration elapses, recording into hist as it goes instead of only
+// returning a final snapshot.
+func RunOpenLoopRPC(ctx context.Context, client benchmark.APIClient, data *benchmark.Data, cfg OpenLoopConfig, hist *LiveHistogram) {
+	tickets := loadgen.Generate(cfg.scheduler(), cfg.Duration, driftWarner("gRPC"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ticket := range tickets {
+				if ctx.Err() != nil {
+					continue
+				}
+				_, err := client.SendData(ctx, data)
+				if err != nil {
+					hist.RecordError()
+					continue
+				}
+				hist.Record(time.Since(ticket.ScheduledAt))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// RunStreamingRPC is the live-reporting counterpart to
+// BenchmarkStreamingRPC: it opens cfg.Streams concurrent bidi streams
+// and records steady-state message latency into hist as responses
+// arrive, instead of only returning a final snapshot once every stream
+// has finished. Establishment latency isn't reported live and is
+// discarded.
+func RunStreamingRPC(ctx context.Context, client benchmark.APIClient, cfg StreamConfig, hist *LiveHistogram) {
+	if cfg.InFlightWindow <= 0 {
+		cfg.InFlightWindow = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.Streams; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runStream(ctx, client, cfg, stats.NewHistogram(), hist)
+		}()
+	}
+	wg.Wait()
+}
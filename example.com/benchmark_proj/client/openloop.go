@@ -0,0 +1,118 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/loadgen"
+	"example.com/benchmark_proj/server"
+	"example.com/benchmark_proj/stats"
+)
+
+// OpenLoopConfig configures an open-loop (or closed-loop, for
+// comparison) concurrency benchmark.
+type OpenLoopConfig struct {
+	LoadType    loadgen.LoadType
+	TargetQPS   float64
+	Duration    time.Duration
+	Concurrency int
+	Seed        int64
+}
+
+func (cfg OpenLoopConfig) scheduler() loadgen.Scheduler {
+	switch cfg.LoadType {
+	case loadgen.OpenPoisson:
+		return loadgen.NewPoissonScheduler(cfg.TargetQPS, cfg.Seed)
+	default:
+		return loadgen.NewConstantScheduler(cfg.TargetQPS)
+	}
+}
+
+func driftWarner(protocol string) func(time.Duration) {
+	return func(behind time.Duration) {
+		log.Printf("%s open-loop generator is %v behind schedule; the client is the bottleneck, not the server under test", protocol, behind)
+	}
+}
+
+// BenchmarkOpenLoopRPC drives gRPC load at cfg.TargetQPS for cfg.Duration
+// using cfg.Concurrency workers pulling from a shared schedule. Latency
+// is measured from each request's scheduled send time, not its actual
+// send time, so that queuing delay caused by the server falling behind
+// is attributed to the request rather than hidden.
+func BenchmarkOpenLoopRPC(client benchmark.APIClient, data *benchmark.Data, cfg OpenLoopConfig) stats.Snapshot {
+	tickets := loadgen.Generate(cfg.scheduler(), cfg.Duration, driftWarner("gRPC"))
+
+	var wg sync.WaitGroup
+	hists := make([]*stats.Histogram, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		h := stats.NewHistogram()
+		hists[i] = h
+		wg.Add(1)
+		go func(h *stats.Histogram) {
+			defer wg.Done()
+			for ticket := range tickets {
+				_, err := client.SendData(context.Background(), data)
+				if err != nil {
+					log.Printf("gRPC API call failed: %v", err)
+					h.RecordError()
+					continue
+				}
+				h.Record(time.Since(ticket.ScheduledAt))
+			}
+		}(h)
+	}
+	wg.Wait()
+
+	merged := stats.NewHistogram()
+	for _, h := range hists {
+		merged.Merge(h)
+	}
+	snapshot := merged.Snapshot(cfg.Duration)
+	fmt.Println("gRPC API - open-loop stats:")
+	fmt.Println(snapshot)
+	return snapshot
+}
+
+// BenchmarkOpenLoopREST is the REST equivalent of BenchmarkOpenLoopRPC.
+func BenchmarkOpenLoopREST(url string, data *server.Data, cfg OpenLoopConfig) stats.Snapshot {
+	tickets := loadgen.Generate(cfg.scheduler(), cfg.Duration, driftWarner("REST"))
+	jsonData, _ := json.Marshal(data)
+
+	var wg sync.WaitGroup
+	hists := make([]*stats.Histogram, cfg.Concurrency)
+	for i := 0; i < cfg.Concurrency; i++ {
+		h := stats.NewHistogram()
+		hists[i] = h
+		wg.Add(1)
+		go func(h *stats.Histogram) {
+			defer wg.Done()
+			for ticket := range tickets {
+				resp, err := http.Post(url, "application/json", bytes.NewBuffer(jsonData))
+				if err != nil {
+					log.Printf("REST API call failed: %v", err)
+					h.RecordError()
+					continue
+				}
+				resp.Body.Close()
+				h.Record(time.Since(ticket.ScheduledAt))
+			}
+		}(h)
+	}
+	wg.Wait()
+
+	merged := stats.NewHistogram()
+	for _, h := range hists {
+		merged.Merge(h)
+	}
+	snapshot := merged.Snapshot(cfg.Duration)
+	fmt.Println("REST API - open-loop stats:")
+	fmt.Println(snapshot)
+	return snapshot
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/stats"
+)
+
+// StreamPacing selects how a streaming benchmark paces sends relative to
+// receives on a single stream.
+type StreamPacing int
+
+const (
+	// PingPong sends one message, waits for its response, then sends
+	// the next - no messages are ever in flight concurrently.
+	PingPong StreamPacing = iota
+	// Pipelined sends up to InFlightWindow messages ahead of the
+	// responses it has received, matching each response back to its
+	// request by sequence number.
+	Pipelined
+)
+
+// StreamConfig configures a streaming RPC benchmark run.
+type StreamConfig struct {
+	Streams           int
+	MessagesPerStream int
+	Pacing            StreamPacing
+	InFlightWindow    int // only used when Pacing == Pipelined
+}
+
+// StreamResult separates stream-establishment latency (time to open the
+// stream) from steady-state per-message latency, since the two have very
+// different characteristics and conflating them hides both.
+type StreamResult struct {
+	Establishment stats.Snapshot
+	SteadyState   stats.Snapshot
+}
+
+// recorder is the subset of *stats.Histogram that runStream and its
+// pacing modes need. It lets callers that require thread-safe recording
+// across goroutines (e.g. RunStreamingRPC, which reports live progress
+// while streams are still in flight) pass in their own wrapper instead
+// of a bare *stats.Histogram.
+type recorder interface {
+	Record(time.Duration)
+	RecordError()
+}
+
+// BenchmarkStreamingRPC opens cfg.Streams concurrent bidi streams against
+// client, sends cfg.MessagesPerStream sequence-numbered messages on each,
+// and reports stream-establishment latency separately from steady-state
+// message latency.
+func BenchmarkStreamingRPC(ctx context.Context, client benchmark.APIClient, cfg StreamConfig) StreamResult {
+	if cfg.InFlightWindow <= 0 {
+		cfg.InFlightWindow = 1
+	}
+
+	var wg sync.WaitGroup
+	establishHists := make([]*stats.Histogram, cfg.Streams)
+	steadyHists := make([]*stats.Histogram, cfg.Streams)
+
+	for i := 0; i < cfg.Streams; i++ {
+		establishHists[i] = stats.NewHistogram()
+		steadyHists[i] = stats.NewHistogram()
+		wg.Add(1)
+		go func(establish, steady *stats.Histogram) {
+			defer wg.Done()
+			runStream(ctx, client, cfg, establish, steady)
+		}(establishHists[i], steadyHists[i])
+	}
+	wg.Wait()
+
+	establishMerged := stats.NewHistogram()
+	steadyMerged := stats.NewHistogram()
+	for i := range establishHists {
+		establishMerged.Merge(establishHists[i])
+		steadyMerged.Merge(steadyHists[i])
+	}
+
+	result := StreamResult{
+		Establishment: establishMerged.Snapshot(0),
+		SteadyState:   steadyMerged.Snapshot(0),
+	}
+	fmt.Println("gRPC streaming - establishment latency:")
+	fmt.Println(result.Establishment)
+	fmt.Println("gRPC streaming - steady-state message latency:")
+	fmt.Println(result.SteadyState)
+	return result
+}
+
+func runStream(ctx context.Context, client benchmark.APIClient, cfg StreamConfig, establish, steady recorder) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	establishStart := time.Now()
+	stream, err := client.StreamData(streamCtx)
+	if err != nil {
+		log.Printf("stream establishment failed: %v", err)
+		establish.RecordError()
+		return
+	}
+	establish.Record(time.Since(establishStart))
+
+	switch cfg.Pacing {
+	case Pipelined:
+		runPipelined(cancel, stream, cfg, steady)
+	default:
+		runPingPong(stream, cfg, steady)
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		log.Printf("stream close failed: %v", err)
+	}
+}
+
+func runPingPong(stream benchmark.API_StreamDataClient, cfg StreamConfig, steady recorder) {
+	for seq := int64(0); seq < int64(cfg.MessagesPerStream); seq++ {
+		sendTime := time.Now()
+		if err := stream.Send(&benchmark.Data{Seq: seq}); err != nil {
+			log.Printf("stream send failed: %v", err)
+			steady.RecordError()
+			return
+		}
+		if _, err := stream.Recv(); err != nil {
+			log.Printf("stream recv failed: %v", err)
+			steady.RecordError()
+			return
+		}
+		steady.Record(time.Since(sendTime))
+	}
+}
+
+// runPipelined sends up to cfg.InFlightWindow messages ahead of the
+// responses it has received. If a send fails partway through, it cancels
+// the stream so the receiver goroutine's pending Recv unblocks with an
+// error instead of waiting forever for responses that will never arrive.
+func runPipelined(cancel context.CancelFunc, stream benchmark.API_StreamDataClient, cfg StreamConfig, steady recorder) {
+	var mu sync.Mutex
+	sendTimes := make(map[int64]time.Time, cfg.InFlightWindow)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < cfg.MessagesPerStream; i++ {
+			resp, err := stream.Recv()
+			if err != nil {
+				log.Printf("stream recv failed: %v", err)
+				steady.RecordError()
+				return
+			}
+			mu.Lock()
+			sendTime, ok := sendTimes[resp.Seq]
+			if ok {
+				delete(sendTimes, resp.Seq)
+			}
+			mu.Unlock()
+			if ok {
+				steady.Record(time.Since(sendTime))
+			}
+		}
+	}()
+
+	inFlight := 0
+	for seq := int64(0); seq < int64(cfg.MessagesPerStream); seq++ {
+		for inFlight >= cfg.InFlightWindow {
+			time.Sleep(time.Microsecond)
+			mu.Lock()
+			inFlight = len(sendTimes)
+			mu.Unlock()
+		}
+
+		mu.Lock()
+		sendTimes[seq] = time.Now()
+		mu.Unlock()
+		if err := stream.Send(&benchmark.Data{Seq: seq}); err != nil {
+			log.Printf("stream send failed: %v", err)
+			steady.RecordError()
+			cancel()
+			break
+		}
+		inFlight++
+	}
+
+	<-done
+}
@@ -0,0 +1,192 @@
+// Command benchmark-client drives load against a benchmark-server and
+// prints payload size and latency comparisons between REST and gRPC.
+// Run standalone for local testing, or pass -control-addr to let a
+// benchmark-driver configure and monitor it remotely instead.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	benchmarkclient "example.com/benchmark_proj/client"
+	"example.com/benchmark_proj/codec"
+	"example.com/benchmark_proj/loadgen"
+	"example.com/benchmark_proj/profiling"
+	"example.com/benchmark_proj/server"
+	"example.com/benchmark_proj/stats"
+	"example.com/benchmark_proj/worker"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	restURL := flag.String("rest-url", "http://localhost:8080/api", "REST endpoint to benchmark")
+	grpcAddr := flag.String("grpc-addr", "localhost:50051", "gRPC address to benchmark")
+	iterations := flag.Int("iterations", 100, "number of sequential calls for the warmup benchmark")
+	concurrency := flag.Int("concurrency", 10, "number of concurrent callers for the concurrency benchmark")
+	controlAddr := flag.String("control-addr", "", "if set, listen here for a benchmark-driver instead of running immediately")
+	stream := flag.Bool("stream", false, "also benchmark the bidi streaming RPC instead of only unary SendData")
+	streamMessages := flag.Int("stream-messages", 1000, "messages sent per stream when -stream is set")
+	pipelined := flag.Bool("stream-pipelined", false, "use pipelined (send-ahead) pacing instead of ping-pong when -stream is set")
+	inFlightWindow := flag.Int("stream-window", 8, "max in-flight messages per stream in pipelined mode")
+	loadType := flag.String("load-type", "closed", "load generation mode: closed, poisson, or constant")
+	targetQPS := flag.Float64("qps", 100, "target requests/sec for -load-type=poisson|constant")
+	openLoopDuration := flag.Duration("open-loop-duration", 10*time.Second, "how long to run the open-loop benchmark")
+	matrix := flag.Bool("matrix", false, "run the transport x codec x compression matrix instead of the standard benchmarks")
+	rawRESTURL := flag.String("raw-rest-url", "http://localhost:8080/api/raw", "REST endpoint used by -matrix, which echoes arbitrary codecs")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this path")
+	memProfile := flag.String("memprofile", "", "write an allocation profile to this path")
+	blockProfile := flag.String("blockprofile", "", "write a blocking profile to this path")
+	mutexProfile := flag.String("mutexprofile", "", "write a mutex contention profile to this path")
+	traceFile := flag.String("trace", "", "write an execution trace to this path")
+	pprofAddr := flag.String("pprof-addr", ":6061", "side address to serve live /debug/pprof/* on")
+	flag.Parse()
+
+	stopProfiling, err := profiling.Start(profiling.Config{
+		CPUProfilePath:   *cpuProfile,
+		MemProfilePath:   *memProfile,
+		BlockProfilePath: *blockProfile,
+		MutexProfilePath: *mutexProfile,
+		TracePath:        *traceFile,
+		PprofAddr:        *pprofAddr,
+	})
+	if err != nil {
+		log.Fatalf("failed to start profiling: %v", err)
+	}
+	defer func() {
+		if err := stopProfiling(); err != nil {
+			log.Printf("failed to flush profiles: %v", err)
+		}
+	}()
+
+	if *controlAddr != "" {
+		runAsWorker(*controlAddr)
+		return
+	}
+
+	if *matrix {
+		conn, err := benchmarkclient.Dial(*grpcAddr)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		defer conn.Close()
+
+		transports := []codec.Transport{
+			codec.NewHTTP1Transport(*rawRESTURL),
+			codec.NewHTTP2Transport(*rawRESTURL),
+			&codec.GRPCTransport{Client: benchmark.NewAPIClient(conn)},
+		}
+		codecs := []codec.Codec{
+			codec.JSONCodec{},
+			codec.ProtobufCodec{},
+			codec.MsgpackCodec{},
+			codec.CBORCodec{},
+			codec.FlatBuffersCodec{},
+		}
+		compressions := []codec.Compression{
+			codec.NoCompression{},
+			codec.GzipCompression{},
+			codec.ZstdCompression{},
+		}
+		value := codec.Value{ID: "123", Name: "Test", Value: 42}
+		results := codec.Run(context.Background(), value, transports, codecs, compressions)
+		codec.PrintTable(results)
+		return
+	}
+
+	data := &server.Data{ID: "123", Name: "Test", Value: 42}
+	protoData := &benchmark.Data{Id: "123", Name: "Test", Value: 42}
+
+	log.Println("Measure Payload size...")
+	benchmarkclient.MeasurePayloadSize(protoData)
+
+	log.Println("Benchmarking REST API...")
+	benchmarkclient.BenchmarkRESTAPI(*restURL, data, *iterations)
+
+	log.Println("Benchmarking RPC API...")
+	conn, err := benchmarkclient.Dial(*grpcAddr)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	defer conn.Close()
+	client := benchmark.NewAPIClient(conn)
+	benchmarkclient.BenchmarkRPCAPI(client, protoData, *iterations)
+
+	log.Println("Benchmarking gRPC API with Concurrency...")
+	var rpcSnapshot stats.Snapshot
+	profiling.MeasurePhase("grpc-concurrency", *iterations, func() {
+		rpcSnapshot = benchmarkclient.BenchmarkConcurrencyRPC(client, protoData, *concurrency, *iterations)
+	})
+
+	log.Println("Benchmarking REST API with Concurrency...")
+	var restSnapshot stats.Snapshot
+	profiling.MeasurePhase("rest-concurrency", *iterations, func() {
+		restSnapshot = benchmarkclient.BenchmarkConcurrencyREST(*restURL, data, *concurrency, *iterations)
+	})
+
+	report := stats.NewReport()
+	report.Add("grpc", rpcSnapshot)
+	report.Add("rest", restSnapshot)
+
+	if *stream {
+		log.Println("Benchmarking gRPC streaming API...")
+		pacing := benchmarkclient.PingPong
+		if *pipelined {
+			pacing = benchmarkclient.Pipelined
+		}
+		streamResult := benchmarkclient.BenchmarkStreamingRPC(context.Background(), client, benchmarkclient.StreamConfig{
+			Streams:           *concurrency,
+			MessagesPerStream: *streamMessages,
+			Pacing:            pacing,
+			InFlightWindow:    *inFlightWindow,
+		})
+		report.Add("grpc-stream-establish", streamResult.Establishment)
+		report.Add("grpc-stream-steady-state", streamResult.SteadyState)
+	}
+
+	if *loadType != "closed" {
+		lt := loadgen.OpenConstant
+		if *loadType == "poisson" {
+			lt = loadgen.OpenPoisson
+		}
+		openLoopCfg := benchmarkclient.OpenLoopConfig{
+			LoadType:    lt,
+			TargetQPS:   *targetQPS,
+			Duration:    *openLoopDuration,
+			Concurrency: *concurrency,
+		}
+		log.Printf("Benchmarking gRPC API open-loop (%s, %.0f qps)...", *loadType, *targetQPS)
+		report.Add("grpc-open-loop", benchmarkclient.BenchmarkOpenLoopRPC(client, protoData, openLoopCfg))
+
+		log.Printf("Benchmarking REST API open-loop (%s, %.0f qps)...", *loadType, *targetQPS)
+		report.Add("rest-open-loop", benchmarkclient.BenchmarkOpenLoopREST(*restURL, data, openLoopCfg))
+	}
+
+	if err := report.WriteJSON("bench_report.json"); err != nil {
+		log.Printf("failed to write JSON report: %v", err)
+	}
+	if err := report.WriteCSV("bench_report.csv"); err != nil {
+		log.Printf("failed to write CSV report: %v", err)
+	}
+}
+
+// runAsWorker registers the WorkerControl service and blocks, waiting
+// for a benchmark-driver to send a ClientConfig over controlAddr.
+func runAsWorker(controlAddr string) {
+	listener, err := net.Listen("tcp", controlAddr)
+	if err != nil {
+		log.Fatalf("failed to listen on control address %s: %v", controlAddr, err)
+	}
+	grpcServer := grpc.NewServer()
+	worker.RegisterWorkerControlServer(grpcServer, worker.NewControlServer(controlAddr))
+
+	log.Printf("awaiting driver commands on %s", controlAddr)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("control server exited: %v", err)
+	}
+}
@@ -0,0 +1,186 @@
+// Command benchmark-driver orchestrates one or more benchmark-server and
+// benchmark-client workers over their WorkerControl control connections,
+// turning the module from a single-binary microbenchmark into a
+// distributed load-testing harness.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"example.com/benchmark_proj/netshape"
+	"example.com/benchmark_proj/worker"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	serverAddrs := flag.String("servers", "", "comma-separated control addresses of benchmark-server workers")
+	clientAddrs := flag.String("clients", "", "comma-separated control addresses of benchmark-client workers")
+	targetRESTAddr := flag.String("target-rest-addr", ":8080", "REST address server workers should listen on")
+	targetGRPCAddr := flag.String("target-grpc-addr", ":50051", "gRPC address server workers should listen on")
+	serverGRPCAddr := flag.String("server-grpc-addr", "localhost:50051", "gRPC address client workers should dial")
+	concurrency := flag.Int("concurrency", 10, "number of concurrent callers each client worker should run")
+	payloadSize := flag.Int("payload-size", 1, "payload size in bytes each client worker should send")
+	warmupSeconds := flag.Int64("warmup-seconds", 0, "duration client workers should warm up for before measuring")
+	measureSeconds := flag.Int64("measure-seconds", 10, "duration client workers should measure load for")
+	loadType := flag.String("load-type", "closed", "load generation mode client workers should use: closed, poisson, or constant")
+	targetQPS := flag.Float64("qps", 100, "target requests/sec for -load-type=poisson|constant")
+	stream := flag.Bool("stream", false, "have client workers run the streaming benchmark instead of unary SendData")
+	streamMessages := flag.Int("stream-messages", 1000, "messages sent per stream when -stream is set")
+	pipelined := flag.Bool("stream-pipelined", false, "use pipelined (send-ahead) pacing instead of ping-pong when -stream is set")
+	inFlightWindow := flag.Int("stream-window", 8, "max in-flight messages per stream in pipelined mode")
+	latency := flag.Duration("latency", 0, "simulated one-way network latency server workers should apply, e.g. 20ms")
+	jitter := flag.Duration("jitter", 0, "simulated latency jitter added on top of -latency")
+	bandwidth := flag.String("bw", "", "simulated bandwidth cap server workers should apply, e.g. 10Mbps")
+	mtu := flag.Int("mtu", 1500, "MTU used to chunk writes when -bw is set")
+	flag.Parse()
+
+	var bandwidthBytesPerSec int64
+	if *bandwidth != "" {
+		bps, err := netshape.ParseBandwidth(*bandwidth)
+		if err != nil {
+			log.Fatalf("invalid -bw: %v", err)
+		}
+		bandwidthBytesPerSec = bps
+	}
+
+	servers := splitAddrs(*serverAddrs)
+	clients := splitAddrs(*clientAddrs)
+	if len(servers) == 0 || len(clients) == 0 {
+		log.Fatal("at least one -servers and one -clients control address is required")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, addr := range servers {
+		addr := addr
+		go runServerWorker(ctx, addr, &worker.ServerConfig{
+			RestAddr:             *targetRESTAddr,
+			GrpcAddr:             *targetGRPCAddr,
+			LatencyNanos:         latency.Nanoseconds(),
+			JitterNanos:          jitter.Nanoseconds(),
+			BandwidthBytesPerSec: bandwidthBytesPerSec,
+			Mtu:                  int32(*mtu),
+		})
+	}
+
+	// Give server workers a moment to start listening before clients dial them.
+	time.Sleep(2 * time.Second)
+
+	var wg sync.WaitGroup
+	for _, addr := range clients {
+		addr := addr
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runClientWorker(ctx, addr, &worker.ClientConfig{
+				ServerAddr:      *serverGRPCAddr,
+				PayloadSize:     int32(*payloadSize),
+				Concurrency:     int32(*concurrency),
+				RpcType:         rpcType(*stream),
+				LoadType:        clientLoadType(*loadType),
+				WarmupSeconds:   *warmupSeconds,
+				MeasureSeconds:  *measureSeconds,
+				StreamMessages:  int32(*streamMessages),
+				StreamPipelined: *pipelined,
+				InFlightWindow:  int32(*inFlightWindow),
+				TargetQps:       *targetQPS,
+			})
+		}()
+	}
+	wg.Wait()
+
+	log.Println("driver run complete")
+}
+
+func rpcType(stream bool) worker.RPCType {
+	if stream {
+		return worker.RPCType_STREAMING
+	}
+	return worker.RPCType_UNARY
+}
+
+func clientLoadType(s string) worker.LoadType {
+	switch s {
+	case "poisson":
+		return worker.LoadType_OPEN_POISSON
+	case "constant":
+		return worker.LoadType_OPEN_CONSTANT
+	default:
+		return worker.LoadType_CLOSED
+	}
+}
+
+func splitAddrs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, a := range strings.Split(s, ",") {
+		a = strings.TrimSpace(a)
+		if a != "" {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// runServerWorker configures a server worker and logs its stats updates
+// until ctx is canceled.
+func runServerWorker(ctx context.Context, addr string, cfg *worker.ServerConfig) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		log.Printf("server worker %s: dial failed: %v", addr, err)
+		return
+	}
+	defer conn.Close()
+
+	stream, err := worker.NewWorkerControlClient(conn).RunServer(ctx, cfg)
+	if err != nil {
+		log.Printf("server worker %s: RunServer failed: %v", addr, err)
+		return
+	}
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return
+		}
+		log.Printf("server worker %s: %+v", addr, update)
+	}
+}
+
+// runClientWorker configures a client worker, collects its stats updates
+// until the stream ends, and logs the final snapshot.
+func runClientWorker(ctx context.Context, addr string, cfg *worker.ClientConfig) {
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		log.Printf("client worker %s: dial failed: %v", addr, err)
+		return
+	}
+	defer conn.Close()
+
+	stream, err := worker.NewWorkerControlClient(conn).RunClient(ctx, cfg)
+	if err != nil {
+		log.Printf("client worker %s: RunClient failed: %v", addr, err)
+		return
+	}
+
+	var last *worker.StatsUpdate
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			break
+		}
+		last = update
+		log.Printf("client worker %s: %+v", addr, update)
+	}
+	if last != nil {
+		log.Printf("client worker %s final: count=%d errors=%d qps=%.1f", addr, last.Count, last.Errors, last.Qps)
+	}
+}
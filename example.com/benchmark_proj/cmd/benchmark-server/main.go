@@ -0,0 +1,76 @@
+// Command benchmark-server serves the REST and gRPC endpoints exercised
+// by benchmark-client. Run standalone for local testing, or pass
+// -control-addr to let a benchmark-driver configure and monitor it
+// remotely instead.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+
+	"example.com/benchmark_proj/netshape"
+	"example.com/benchmark_proj/profiling"
+	"example.com/benchmark_proj/server"
+	"example.com/benchmark_proj/worker"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	restAddr := flag.String("rest-addr", ":8080", "address for the REST API to listen on")
+	grpcAddr := flag.String("grpc-addr", ":50051", "address for the gRPC API to listen on")
+	controlAddr := flag.String("control-addr", "", "if set, listen here for a benchmark-driver instead of serving immediately")
+	latency := flag.Duration("latency", 0, "simulated one-way network latency, e.g. 20ms")
+	jitter := flag.Duration("jitter", 0, "simulated latency jitter added on top of -latency, e.g. 5ms")
+	bandwidth := flag.String("bw", "", "simulated bandwidth cap, e.g. 10Mbps")
+	mtu := flag.Int("mtu", 1500, "MTU used to chunk writes when -bw is set")
+	cpuProfile := flag.String("cpuprofile", "", "write a CPU profile to this path")
+	memProfile := flag.String("memprofile", "", "write an allocation profile to this path")
+	pprofAddr := flag.String("pprof-addr", ":6060", "side address to serve live /debug/pprof/* on")
+	flag.Parse()
+
+	stopProfiling, err := profiling.Start(profiling.Config{
+		CPUProfilePath: *cpuProfile,
+		MemProfilePath: *memProfile,
+		PprofAddr:      *pprofAddr,
+	})
+	if err != nil {
+		log.Fatalf("failed to start profiling: %v", err)
+	}
+	defer func() {
+		if err := stopProfiling(); err != nil {
+			log.Printf("failed to flush profiles: %v", err)
+		}
+	}()
+
+	shape := netshape.Config{Latency: *latency, Jitter: *jitter, MTU: *mtu}
+	if *bandwidth != "" {
+		bps, err := netshape.ParseBandwidth(*bandwidth)
+		if err != nil {
+			log.Fatalf("invalid -bw: %v", err)
+		}
+		shape.BandwidthBytesPerSec = bps
+	}
+
+	if *controlAddr == "" {
+		log.Printf("serving REST on %s and gRPC on %s", *restAddr, *grpcAddr)
+		if err := server.Run(context.Background(), server.Config{RESTAddr: *restAddr, GRPCAddr: *grpcAddr, NetShape: shape}); err != nil {
+			log.Fatalf("server exited: %v", err)
+		}
+		return
+	}
+
+	listener, err := net.Listen("tcp", *controlAddr)
+	if err != nil {
+		log.Fatalf("failed to listen on control address %s: %v", *controlAddr, err)
+	}
+	grpcServer := grpc.NewServer()
+	worker.RegisterWorkerControlServer(grpcServer, worker.NewControlServer(*controlAddr))
+
+	log.Printf("awaiting driver commands on %s", *controlAddr)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("control server exited: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package codec
+
+import "github.com/fxamacker/cbor/v2"
+
+type cborValue struct {
+	ID    string `cbor:"id"`
+	Name  string `cbor:"name"`
+	Value int32  `cbor:"value"`
+}
+
+// CBORCodec encodes a Value as CBOR (RFC 8949), a binary format similar
+// in spirit to MessagePack but with an IETF standard behind it.
+type CBORCodec struct{}
+
+func (CBORCodec) Name() string        { return "cbor" }
+func (CBORCodec) ContentType() string { return "application/cbor" }
+
+func (CBORCodec) Marshal(v Value) ([]byte, error) {
+	return cbor.Marshal(cborValue{ID: v.ID, Name: v.Name, Value: v.Value})
+}
+
+func (CBORCodec) Unmarshal(data []byte) (Value, error) {
+	var cv cborValue
+	if err := cbor.Unmarshal(data, &cv); err != nil {
+		return Value{}, err
+	}
+	return Value{ID: cv.ID, Name: cv.Name, Value: cv.Value}, nil
+}
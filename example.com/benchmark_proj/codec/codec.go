@@ -0,0 +1,45 @@
+// Package codec generalizes this module's REST-JSON vs gRPC-Protobuf
+// comparison into a full matrix of {transport} x {codec} x {compression},
+// so serialization format and wire transport can be attributed
+// separately instead of conflated into a single "REST vs gRPC" number.
+package codec
+
+import "context"
+
+// Value is the canonical, codec-agnostic form of the payload this
+// module benchmarks - the same fields as benchmark.Data and server.Data,
+// but independent of either's encoding.
+type Value struct {
+	ID    string
+	Name  string
+	Value int32
+}
+
+// Codec marshals and unmarshals a Value to and from a specific wire
+// format.
+type Codec interface {
+	// Name identifies the codec, e.g. "json" or "protobuf".
+	Name() string
+	// ContentType is the MIME type sent with the encoded payload.
+	ContentType() string
+	Marshal(v Value) ([]byte, error)
+	Unmarshal(data []byte) (Value, error)
+}
+
+// Compression compresses and decompresses an already-encoded payload,
+// independent of which Codec produced it.
+type Compression interface {
+	// Name identifies the compression scheme, e.g. "none" or "gzip".
+	Name() string
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+// Transport sends an already-encoded (and possibly compressed) payload
+// to a server and returns whatever it echoes back, independent of which
+// Codec or Compression produced the payload.
+type Transport interface {
+	// Name identifies the transport, e.g. "http/1.1", "http/2", or "grpc".
+	Name() string
+	Call(ctx context.Context, contentType string, payload []byte) ([]byte, error)
+}
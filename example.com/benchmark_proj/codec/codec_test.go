@@ -0,0 +1,55 @@
+package codec
+
+import "testing"
+
+func TestCodecRoundTrip(t *testing.T) {
+	v := Value{ID: "123", Name: "Test", Value: 42}
+
+	codecs := []Codec{
+		JSONCodec{},
+		ProtobufCodec{},
+		MsgpackCodec{},
+		CBORCodec{},
+		FlatBuffersCodec{},
+	}
+	for _, c := range codecs {
+		t.Run(c.Name(), func(t *testing.T) {
+			data, err := c.Marshal(v)
+			if err != nil {
+				t.Fatalf("Marshal: %v", err)
+			}
+			got, err := c.Unmarshal(data)
+			if err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if got != v {
+				t.Errorf("round trip = %+v, want %+v", got, v)
+			}
+		})
+	}
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	data := []byte("the quick brown fox jumps over the lazy dog, repeated for better compression: the quick brown fox jumps over the lazy dog")
+
+	compressions := []Compression{
+		NoCompression{},
+		GzipCompression{},
+		ZstdCompression{},
+	}
+	for _, c := range compressions {
+		t.Run(c.Name(), func(t *testing.T) {
+			compressed, err := c.Compress(data)
+			if err != nil {
+				t.Fatalf("Compress: %v", err)
+			}
+			got, err := c.Decompress(compressed)
+			if err != nil {
+				t.Fatalf("Decompress: %v", err)
+			}
+			if string(got) != string(data) {
+				t.Errorf("round trip = %q, want %q", got, data)
+			}
+		})
+	}
+}
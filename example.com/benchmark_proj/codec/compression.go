@@ -0,0 +1,84 @@
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// NoCompression passes data through unchanged, used as the baseline
+// column in the codec matrix.
+type NoCompression struct{}
+
+func (NoCompression) Name() string { return "none" }
+
+func (NoCompression) Compress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (NoCompression) Decompress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// GzipCompression compresses with the standard library's gzip
+// implementation, matching what grpc.WithCompressor(gzip) and most HTTP
+// servers support out of the box.
+type GzipCompression struct{}
+
+func (GzipCompression) Name() string { return "gzip" }
+
+func (GzipCompression) Compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, fmt.Errorf("gzip compress: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("gzip compress: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (GzipCompression) Decompress(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompress: %w", err)
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("gzip decompress: %w", err)
+	}
+	return out, nil
+}
+
+// ZstdCompression compresses with zstd, generally faster and denser than
+// gzip at comparable levels.
+type ZstdCompression struct{}
+
+func (ZstdCompression) Name() string { return "zstd" }
+
+func (ZstdCompression) Compress(data []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("zstd compress: %w", err)
+	}
+	defer enc.Close()
+	return enc.EncodeAll(data, nil), nil
+}
+
+func (ZstdCompression) Decompress(data []byte) ([]byte, error) {
+	dec, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("zstd decompress: %w", err)
+	}
+	defer dec.Close()
+	out, err := dec.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("zstd decompress: %w", err)
+	}
+	return out, nil
+}
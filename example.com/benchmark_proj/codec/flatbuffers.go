@@ -0,0 +1,56 @@
+package codec
+
+import (
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// Field offsets within the flatbuffers vtable, in declaration order:
+// id (string), name (string), value (int32).
+const (
+	fbFieldID = iota
+	fbFieldName
+	fbFieldValue
+)
+
+// FlatBuffersCodec encodes a Value as a FlatBuffers table built directly
+// against the flatbuffers Go runtime. The schema is small and stable
+// enough that hand-building the table here is clearer than round
+// tripping through flatc-generated accessors for three fields.
+type FlatBuffersCodec struct{}
+
+func (FlatBuffersCodec) Name() string        { return "flatbuffers" }
+func (FlatBuffersCodec) ContentType() string { return "application/x-flatbuffers" }
+
+func (FlatBuffersCodec) Marshal(v Value) ([]byte, error) {
+	b := flatbuffers.NewBuilder(64)
+	idOff := b.CreateString(v.ID)
+	nameOff := b.CreateString(v.Name)
+
+	b.StartObject(3)
+	b.PrependUOffsetTSlot(fbFieldID, idOff, 0)
+	b.PrependUOffsetTSlot(fbFieldName, nameOff, 0)
+	b.PrependInt32Slot(fbFieldValue, v.Value, 0)
+	root := b.EndObject()
+
+	b.Finish(root)
+	return b.FinishedBytes(), nil
+}
+
+func (FlatBuffersCodec) Unmarshal(data []byte) (Value, error) {
+	table := &flatbuffers.Table{
+		Bytes: data,
+		Pos:   flatbuffers.GetUOffsetT(data),
+	}
+
+	var out Value
+	if o := table.Offset(flatbuffers.VOffsetT((fbFieldID + 2) * 2)); o != 0 {
+		out.ID = string(table.ByteVector(flatbuffers.UOffsetT(o) + table.Pos))
+	}
+	if o := table.Offset(flatbuffers.VOffsetT((fbFieldName + 2) * 2)); o != 0 {
+		out.Name = string(table.ByteVector(flatbuffers.UOffsetT(o) + table.Pos))
+	}
+	if o := table.Offset(flatbuffers.VOffsetT((fbFieldValue + 2) * 2)); o != 0 {
+		out.Value = table.GetInt32(flatbuffers.UOffsetT(o) + table.Pos)
+	}
+	return out, nil
+}
@@ -0,0 +1,28 @@
+package codec
+
+import "encoding/json"
+
+type jsonValue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value int32  `json:"value"`
+}
+
+// JSONCodec encodes a Value using encoding/json, the format this
+// module's REST endpoint has always used.
+type JSONCodec struct{}
+
+func (JSONCodec) Name() string        { return "json" }
+func (JSONCodec) ContentType() string { return "application/json" }
+
+func (JSONCodec) Marshal(v Value) ([]byte, error) {
+	return json.Marshal(jsonValue{ID: v.ID, Name: v.Name, Value: v.Value})
+}
+
+func (JSONCodec) Unmarshal(data []byte) (Value, error) {
+	var jv jsonValue
+	if err := json.Unmarshal(data, &jv); err != nil {
+		return Value{}, err
+	}
+	return Value{ID: jv.ID, Name: jv.Name, Value: jv.Value}, nil
+}
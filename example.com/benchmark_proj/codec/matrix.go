@@ -0,0 +1,86 @@
+package codec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Result is one cell of the transport x codec x compression matrix.
+type Result struct {
+	Transport     string
+	Codec         string
+	Compression   string
+	EncodedBytes  int
+	OnWireBytes   int
+	RoundTripTime time.Duration
+	Err           error
+}
+
+// Run exercises every combination of transports, codecs, and
+// compressions against v, returning one Result per cell so the caller
+// can attribute cost to transport, encoding, or compression separately
+// instead of a single conflated "REST vs gRPC" number.
+func Run(ctx context.Context, v Value, transports []Transport, codecs []Codec, compressions []Compression) []Result {
+	var results []Result
+	for _, tr := range transports {
+		for _, c := range codecs {
+			for _, comp := range compressions {
+				results = append(results, runOne(ctx, v, tr, c, comp))
+			}
+		}
+	}
+	return results
+}
+
+func runOne(ctx context.Context, v Value, tr Transport, c Codec, comp Compression) Result {
+	result := Result{Transport: tr.Name(), Codec: c.Name(), Compression: comp.Name()}
+
+	encoded, err := c.Marshal(v)
+	if err != nil {
+		result.Err = fmt.Errorf("marshal: %w", err)
+		return result
+	}
+	result.EncodedBytes = len(encoded)
+
+	onWire, err := comp.Compress(encoded)
+	if err != nil {
+		result.Err = fmt.Errorf("compress: %w", err)
+		return result
+	}
+	result.OnWireBytes = len(onWire)
+
+	start := time.Now()
+	respWire, err := tr.Call(ctx, c.ContentType(), onWire)
+	if err != nil {
+		result.Err = fmt.Errorf("call: %w", err)
+		return result
+	}
+	result.RoundTripTime = time.Since(start)
+
+	respEncoded, err := comp.Decompress(respWire)
+	if err != nil {
+		result.Err = fmt.Errorf("decompress: %w", err)
+		return result
+	}
+	if _, err := c.Unmarshal(respEncoded); err != nil {
+		result.Err = fmt.Errorf("unmarshal: %w", err)
+	}
+	return result
+}
+
+// PrintTable renders results as a fixed-width table keyed by
+// (transport, codec, compression), so payload size and round-trip cost
+// can be attributed to each dimension independently.
+func PrintTable(results []Result) {
+	fmt.Printf("%-8s %-12s %-10s %10s %10s %12s\n", "transport", "codec", "compress", "encoded", "on-wire", "round-trip")
+	fmt.Println(strings.Repeat("-", 66))
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Printf("%-8s %-12s %-10s %10s %10s %12s  error: %v\n", r.Transport, r.Codec, r.Compression, "-", "-", "-", r.Err)
+			continue
+		}
+		fmt.Printf("%-8s %-12s %-10s %10d %10d %12s\n", r.Transport, r.Codec, r.Compression, r.EncodedBytes, r.OnWireBytes, r.RoundTripTime)
+	}
+}
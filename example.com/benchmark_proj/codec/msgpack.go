@@ -0,0 +1,28 @@
+package codec
+
+import "github.com/vmihailenco/msgpack/v5"
+
+type msgpackValue struct {
+	ID    string `msgpack:"id"`
+	Name  string `msgpack:"name"`
+	Value int32  `msgpack:"value"`
+}
+
+// MsgpackCodec encodes a Value as MessagePack, a denser binary
+// alternative to JSON that still requires no schema.
+type MsgpackCodec struct{}
+
+func (MsgpackCodec) Name() string        { return "msgpack" }
+func (MsgpackCodec) ContentType() string { return "application/msgpack" }
+
+func (MsgpackCodec) Marshal(v Value) ([]byte, error) {
+	return msgpack.Marshal(msgpackValue{ID: v.ID, Name: v.Name, Value: v.Value})
+}
+
+func (MsgpackCodec) Unmarshal(data []byte) (Value, error) {
+	var mv msgpackValue
+	if err := msgpack.Unmarshal(data, &mv); err != nil {
+		return Value{}, err
+	}
+	return Value{ID: mv.ID, Name: mv.Name, Value: mv.Value}, nil
+}
@@ -0,0 +1,26 @@
+package codec
+
+import (
+	"example.com/benchmark_proj/benchmark"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ProtobufCodec encodes a Value as a benchmark.Data protobuf message,
+// the format this module's gRPC endpoint has always used.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Name() string        { return "protobuf" }
+func (ProtobufCodec) ContentType() string { return "application/x-protobuf" }
+
+func (ProtobufCodec) Marshal(v Value) ([]byte, error) {
+	return proto.Marshal(&benchmark.Data{Id: v.ID, Name: v.Name, Value: v.Value})
+}
+
+func (ProtobufCodec) Unmarshal(data []byte) (Value, error) {
+	var d benchmark.Data
+	if err := proto.Unmarshal(data, &d); err != nil {
+		return Value{}, err
+	}
+	return Value{ID: d.Id, Name: d.Name, Value: d.Value}, nil
+}
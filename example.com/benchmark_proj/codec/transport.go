@@ -0,0 +1,83 @@
+package codec
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"example.com/benchmark_proj/benchmark"
+
+	"golang.org/x/net/http2"
+)
+
+// HTTPTransport posts an already-encoded payload to a REST endpoint and
+// returns whatever it echoes back. Whether it speaks HTTP/1.1 or HTTP/2
+// is entirely determined by the *http.Client it's built with.
+type HTTPTransport struct {
+	Client *http.Client
+	URL    string
+	name   string
+}
+
+// NewHTTP1Transport returns an HTTPTransport that speaks plain HTTP/1.1.
+func NewHTTP1Transport(url string) *HTTPTransport {
+	return &HTTPTransport{Client: &http.Client{}, URL: url, name: "http/1.1"}
+}
+
+// NewHTTP2Transport returns an HTTPTransport that forces HTTP/2 via
+// prior-knowledge cleartext (h2c), so the comparison doesn't require TLS
+// certificates in a benchmarking setup.
+func NewHTTP2Transport(url string) *HTTPTransport {
+	client := &http.Client{
+		Transport: &http2.Transport{
+			AllowHTTP: true,
+			DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+				return net.Dial(network, addr)
+			},
+		},
+	}
+	return &HTTPTransport{Client: client, URL: url, name: "http/2"}
+}
+
+func (t *HTTPTransport) Name() string { return t.name }
+
+func (t *HTTPTransport) Call(ctx context.Context, contentType string, payload []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := t.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s call: %w", t.name, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s read response: %w", t.name, err)
+	}
+	return body, nil
+}
+
+// GRPCTransport sends an already-encoded payload over the gRPC
+// SendRaw RPC, letting any Codec be compared over the gRPC transport
+// without a dedicated RPC per codec.
+type GRPCTransport struct {
+	Client benchmark.APIClient
+}
+
+func (t *GRPCTransport) Name() string { return "grpc" }
+
+func (t *GRPCTransport) Call(ctx context.Context, contentType string, payload []byte) ([]byte, error) {
+	resp, err := t.Client.SendRaw(ctx, &benchmark.RawEnvelope{Payload: payload, ContentType: contentType})
+	if err != nil {
+		return nil, fmt.Errorf("grpc call: %w", err)
+	}
+	return resp.Payload, nil
+}
@@ -0,0 +1,124 @@
+// Package loadgen schedules request send times independently of how
+// long a server takes to respond, so that client-side concurrency
+// benchmarks can run open-loop instead of closed-loop. Closed-loop
+// benchmarks (each caller waits for its own response before sending
+// again) understate tail latency under saturation because a slow
+// response simply delays the next send instead of queuing up; open-loop
+// generators schedule sends on a fixed timeline regardless of how the
+// server is performing.
+package loadgen
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// LoadType selects how request send times are scheduled.
+type LoadType int
+
+const (
+	// Closed is the traditional mode: a caller sends its next request
+	// only after its previous one completes.
+	Closed LoadType = iota
+	// OpenPoisson schedules sends at inter-arrival times drawn from a
+	// Poisson process, matching bursty real-world traffic.
+	OpenPoisson
+	// OpenConstant schedules sends at a fixed interval, useful for
+	// isolating queuing effects from arrival-process variance.
+	OpenConstant
+)
+
+// Scheduler produces successive inter-arrival durations for a target
+// request rate.
+type Scheduler interface {
+	// Next returns the duration to wait before the next scheduled send.
+	Next() time.Duration
+}
+
+// poissonScheduler draws inter-arrival times from an exponential
+// distribution, which is the inter-arrival distribution of a Poisson
+// arrival process with rate qps.
+type poissonScheduler struct {
+	meanInterval float64 // seconds
+	rng          *rand.Rand
+}
+
+// NewPoissonScheduler returns a Scheduler whose sends arrive as a
+// Poisson process with the given target rate in requests/sec.
+func NewPoissonScheduler(qps float64, seed int64) Scheduler {
+	return &poissonScheduler{
+		meanInterval: 1 / qps,
+		rng:          rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (p *poissonScheduler) Next() time.Duration {
+	// Inverse-CDF sampling: -ln(U)/lambda, where lambda = 1/meanInterval.
+	u := p.rng.Float64()
+	for u == 0 {
+		u = p.rng.Float64()
+	}
+	seconds := -math.Log(u) * p.meanInterval
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// constantScheduler sends at a fixed interval derived from the target
+// rate.
+type constantScheduler struct {
+	interval time.Duration
+}
+
+// NewConstantScheduler returns a Scheduler that sends exactly once per
+// interval implied by the given target rate in requests/sec.
+func NewConstantScheduler(qps float64) Scheduler {
+	return &constantScheduler{interval: time.Duration(float64(time.Second) / qps)}
+}
+
+func (c *constantScheduler) Next() time.Duration {
+	return c.interval
+}
+
+// Ticket is a single scheduled send slot. ScheduledAt is the time the
+// send was supposed to happen; latency should be measured against this,
+// not against the time the send actually occurred, so that queuing delay
+// caused by the client or server falling behind is attributed to the
+// request rather than hidden (the "coordinated omission" correction).
+type Ticket struct {
+	Seq         int64
+	ScheduledAt time.Time
+}
+
+// Generate schedules tickets at the rate implied by sched for duration
+// and sends them on the returned channel as each scheduled time arrives.
+// The channel is closed once duration has elapsed. driftWarn is called
+// whenever the generator falls more than one interval behind schedule,
+// indicating the generator itself (not the system under test) is the
+// bottleneck.
+func Generate(sched Scheduler, duration time.Duration, driftWarn func(behind time.Duration)) <-chan Ticket {
+	out := make(chan Ticket)
+	go func() {
+		defer close(out)
+		start := time.Now()
+		deadline := start.Add(duration)
+		next := start
+		var seq int64
+		for {
+			interval := sched.Next()
+			next = next.Add(interval)
+			if next.After(deadline) {
+				return
+			}
+			if behind := time.Until(next); behind < -interval {
+				if driftWarn != nil {
+					driftWarn(-behind)
+				}
+			} else {
+				time.Sleep(time.Until(next))
+			}
+			out <- Ticket{Seq: seq, ScheduledAt: next}
+			seq++
+		}
+	}()
+	return out
+}
@@ -0,0 +1,76 @@
+package loadgen
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConstantSchedulerInterval(t *testing.T) {
+	cases := []struct {
+		qps  float64
+		want time.Duration
+	}{
+		{100, 10 * time.Millisecond},
+		{1000, time.Millisecond},
+		{1, time.Second},
+	}
+	for _, c := range cases {
+		s := NewConstantScheduler(c.qps)
+		if got := s.Next(); got != c.want {
+			t.Errorf("NewConstantScheduler(%v).Next() = %v, want %v", c.qps, got, c.want)
+		}
+	}
+}
+
+func TestPoissonSchedulerMeanInterval(t *testing.T) {
+	const qps = 500.0
+	s := NewPoissonScheduler(qps, 1)
+
+	var sum time.Duration
+	const samples = 20000
+	for i := 0; i < samples; i++ {
+		sum += s.Next()
+	}
+	mean := sum / samples
+	want := time.Duration(float64(time.Second) / qps)
+
+	// Exponential inter-arrivals are noisy; check the empirical mean
+	// lands within 10% of the target rather than expecting an exact
+	// match.
+	tolerance := want / 10
+	if diff := mean - want; diff < -tolerance || diff > tolerance {
+		t.Errorf("mean inter-arrival = %v, want ~%v (tolerance %v)", mean, want, tolerance)
+	}
+}
+
+func TestGenerateRespectsDuration(t *testing.T) {
+	sched := NewConstantScheduler(1000) // 1ms apart
+	duration := 50 * time.Millisecond
+
+	start := time.Now()
+	var count int64
+	for range Generate(sched, duration, nil) {
+		count++
+	}
+	elapsed := time.Since(start)
+
+	if count == 0 {
+		t.Fatal("Generate produced no tickets")
+	}
+	// Generous bound: the channel must close at or shortly after
+	// duration, not run away indefinitely.
+	if elapsed > duration+500*time.Millisecond {
+		t.Errorf("Generate ran for %v, want close to %v", elapsed, duration)
+	}
+}
+
+func TestGenerateSequenceNumbersAreOrdered(t *testing.T) {
+	sched := NewConstantScheduler(2000) // 0.5ms apart
+	var lastSeq int64 = -1
+	for ticket := range Generate(sched, 20*time.Millisecond, nil) {
+		if ticket.Seq <= lastSeq {
+			t.Fatalf("ticket.Seq = %d, want > %d", ticket.Seq, lastSeq)
+		}
+		lastSeq = ticket.Seq
+	}
+}
@@ -0,0 +1,207 @@
+// Package netshape wraps a net.Listener/net.Conn to inject a fixed
+// one-way latency, optional jitter, and a token-bucket bandwidth cap on
+// reads and writes. Benchmarking against localhost masks the
+// serialization-size effects a real network link would expose; wrapping
+// the listener used by http.Server or grpc.NewServer lets a benchmark
+// see how payload size actually pays off on a slow link.
+package netshape
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config describes the network conditions to simulate.
+type Config struct {
+	// Latency is the fixed one-way delay added to every read and write.
+	Latency time.Duration
+	// Jitter is the maximum additional random delay added on top of
+	// Latency, uniformly distributed in [0, Jitter).
+	Jitter time.Duration
+	// BandwidthBytesPerSec caps read and write throughput via a token
+	// bucket. Zero means unlimited.
+	BandwidthBytesPerSec int64
+	// MTU chunks writes larger than this size so that bandwidth shaping
+	// is applied smoothly instead of in one lump sleep. Zero disables
+	// chunking.
+	MTU int
+}
+
+// ParseBandwidth parses strings like "10Mbps", "500Kbps", or "1Gbps"
+// into bytes/sec, matching the -bw flag format.
+func ParseBandwidth(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	if !strings.HasSuffix(lower, "bps") {
+		return 0, fmt.Errorf("invalid bandwidth %q: must end in bps", s)
+	}
+	numPart := strings.TrimSuffix(lower, "bps")
+
+	var multiplier float64 = 1
+	switch {
+	case strings.HasSuffix(numPart, "k"):
+		multiplier = 1e3
+		numPart = strings.TrimSuffix(numPart, "k")
+	case strings.HasSuffix(numPart, "m"):
+		multiplier = 1e6
+		numPart = strings.TrimSuffix(numPart, "m")
+	case strings.HasSuffix(numPart, "g"):
+		multiplier = 1e9
+		numPart = strings.TrimSuffix(numPart, "g")
+	}
+
+	bits, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bandwidth %q: %w", s, err)
+	}
+	return int64(bits * multiplier / 8), nil
+}
+
+// Listener wraps a net.Listener so every accepted connection is shaped
+// according to cfg.
+type Listener struct {
+	net.Listener
+	cfg Config
+}
+
+// NewListener returns a Listener that shapes every connection it
+// accepts according to cfg.
+func NewListener(inner net.Listener, cfg Config) *Listener {
+	return &Listener{Listener: inner, cfg: cfg}
+}
+
+// Accept accepts the next connection and wraps it in a shaped Conn.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewConn(conn, l.cfg), nil
+}
+
+// Conn wraps a net.Conn, delaying and rate-limiting reads and writes
+// according to cfg.
+type Conn struct {
+	net.Conn
+	cfg      Config
+	rng      *rand.Rand
+	rngMu    sync.Mutex
+	readLim  *tokenBucket
+	writeLim *tokenBucket
+}
+
+// NewConn returns a Conn that shapes traffic over inner according to
+// cfg.
+func NewConn(inner net.Conn, cfg Config) *Conn {
+	return &Conn{
+		Conn:     inner,
+		cfg:      cfg,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		readLim:  newTokenBucket(cfg.BandwidthBytesPerSec),
+		writeLim: newTokenBucket(cfg.BandwidthBytesPerSec),
+	}
+}
+
+// Read reads from the underlying connection, then applies the
+// bandwidth cap and one-way latency before returning to the caller.
+func (c *Conn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	if n > 0 {
+		c.readLim.consume(n)
+		c.delay()
+	}
+	return n, err
+}
+
+// Write chunks b into MTU-sized segments (if cfg.MTU is set), applying
+// the bandwidth cap and one-way latency to each segment so shaping is
+// smooth rather than one lump sleep per call.
+func (c *Conn) Write(b []byte) (int, error) {
+	chunkSize := len(b)
+	if c.cfg.MTU > 0 && c.cfg.MTU < chunkSize {
+		chunkSize = c.cfg.MTU
+	}
+	if chunkSize == 0 {
+		chunkSize = len(b)
+	}
+
+	var written int
+	for written < len(b) {
+		end := written + chunkSize
+		if end > len(b) {
+			end = len(b)
+		}
+		n, err := c.Conn.Write(b[written:end])
+		written += n
+		if n > 0 {
+			c.writeLim.consume(n)
+			c.delay()
+		}
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (c *Conn) delay() {
+	d := c.cfg.Latency
+	if c.cfg.Jitter > 0 {
+		c.rngMu.Lock()
+		d += time.Duration(c.rng.Int63n(int64(c.cfg.Jitter)))
+		c.rngMu.Unlock()
+	}
+	if d > 0 {
+		time.Sleep(d)
+	}
+}
+
+// tokenBucket rate-limits byte throughput to ratePerSec bytes/sec. A nil
+// tokenBucket (ratePerSec == 0) never blocks.
+type tokenBucket struct {
+	mu         sync.Mutex
+	ratePerSec float64
+	capacity   float64
+	tokens     float64
+	last       time.Time
+}
+
+func newTokenBucket(bytesPerSec int64) *tokenBucket {
+	if bytesPerSec <= 0 {
+		return nil
+	}
+	return &tokenBucket{
+		ratePerSec: float64(bytesPerSec),
+		capacity:   float64(bytesPerSec),
+		tokens:     float64(bytesPerSec),
+		last:       time.Now(),
+	}
+}
+
+func (t *tokenBucket) consume(n int) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	t.tokens += now.Sub(t.last).Seconds() * t.ratePerSec
+	if t.tokens > t.capacity {
+		t.tokens = t.capacity
+	}
+	t.last = now
+
+	t.tokens -= float64(n)
+	if t.tokens < 0 {
+		wait := -t.tokens / t.ratePerSec
+		time.Sleep(time.Duration(wait * float64(time.Second)))
+		t.tokens = 0
+		t.last = time.Now()
+	}
+}
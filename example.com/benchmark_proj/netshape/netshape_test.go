@@ -0,0 +1,67 @@
+package netshape
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBandwidth(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"10Mbps", 10e6 / 8, false},
+		{"500Kbps", 500e3 / 8, false},
+		{"1Gbps", 1e9 / 8, false},
+		{"8bps", 1, false},
+		{"", 0, true},
+		{"10Mbit", 0, true},
+		{"abcbps", 0, true},
+	}
+	for _, c := range cases {
+		got, err := ParseBandwidth(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("ParseBandwidth(%q) = %d, nil, want error", c.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseBandwidth(%q) returned error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("ParseBandwidth(%q) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
+
+func TestTokenBucketNilIsUnlimited(t *testing.T) {
+	var tb *tokenBucket // newTokenBucket(0) returns nil
+	start := time.Now()
+	tb.consume(1 << 30)
+	if time.Since(start) > 10*time.Millisecond {
+		t.Errorf("consume on a nil (unlimited) tokenBucket should not block")
+	}
+}
+
+func TestTokenBucketConsumeBlocksWhenExhausted(t *testing.T) {
+	tb := newTokenBucket(100) // 100 bytes/sec
+	// First consume drains the initial full bucket (100 tokens)
+	// immediately.
+	start := time.Now()
+	tb.consume(100)
+	if elapsed := time.Since(start); elapsed > 10*time.Millisecond {
+		t.Fatalf("first consume blocked for %v, want ~0", elapsed)
+	}
+
+	// The bucket is now empty; consuming another 50 bytes at 100
+	// bytes/sec should block for roughly 500ms.
+	start = time.Now()
+	tb.consume(50)
+	elapsed := time.Since(start)
+	if elapsed < 300*time.Millisecond || elapsed > 800*time.Millisecond {
+		t.Errorf("second consume blocked for %v, want ~500ms", elapsed)
+	}
+}
@@ -0,0 +1,50 @@
+package profiling
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// PhaseStats reports the allocation and GC cost of one benchmark phase,
+// the missing half of the story next to latency: an encoder that's fast
+// but allocates heavily still pressures the GC and pays for it later.
+type PhaseStats struct {
+	Name         string
+	Iterations   int
+	AllocsPerOp  uint64
+	BytesPerOp   uint64
+	NumGC        uint32
+	PauseTotalNs time.Duration
+}
+
+// MeasurePhase runs fn once (fn is expected to loop iterations times
+// internally, matching this module's existing benchmark functions),
+// bookending it with runtime.GC() and a runtime.MemStats snapshot so the
+// resulting PhaseStats reflects only what fn allocated.
+func MeasurePhase(name string, iterations int, fn func()) PhaseStats {
+	runtime.GC()
+	var before runtime.MemStats
+	runtime.ReadMemStats(&before)
+
+	fn()
+
+	var after runtime.MemStats
+	runtime.ReadMemStats(&after)
+
+	stats := PhaseStats{Name: name, Iterations: iterations}
+	if iterations > 0 {
+		stats.AllocsPerOp = (after.Mallocs - before.Mallocs) / uint64(iterations)
+		stats.BytesPerOp = (after.TotalAlloc - before.TotalAlloc) / uint64(iterations)
+	}
+	stats.NumGC = after.NumGC - before.NumGC
+	stats.PauseTotalNs = time.Duration(after.PauseTotalNs - before.PauseTotalNs)
+
+	fmt.Println(stats)
+	return stats
+}
+
+func (s PhaseStats) String() string {
+	return fmt.Sprintf("  %s: %d allocs/op, %d bytes/op, %d GCs, %v GC pause",
+		s.Name, s.AllocsPerOp, s.BytesPerOp, s.NumGC, s.PauseTotalNs)
+}
@@ -0,0 +1,121 @@
+// Package profiling wires up CPU/block/mutex profiling, execution
+// tracing, and an always-on pprof HTTP endpoint, plus per-phase
+// allocation accounting so the bytes/op and allocs/op difference
+// between codecs (e.g. JSON's allocation-heavy encoder vs Protobuf's)
+// shows up alongside latency instead of being invisible.
+package profiling
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	_ "net/http/pprof" // registers the pprof handlers on http.DefaultServeMux
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// Config selects which profiles to collect during a run. Empty paths
+// disable the corresponding profile.
+type Config struct {
+	CPUProfilePath   string
+	MemProfilePath   string
+	BlockProfilePath string
+	MutexProfilePath string
+	TracePath        string
+	PprofAddr        string // side HTTP port for live /debug/pprof/*, e.g. ":6060"
+}
+
+// stopFunc flushes and closes whatever a Start call opened.
+type stopFunc func() error
+
+// Start begins whichever profiles cfg requests and starts the side
+// pprof HTTP server if cfg.PprofAddr is set. The returned stop function
+// must be called before the process exits to flush profile data.
+func Start(cfg Config) (stopFunc, error) {
+	var closers []func() error
+
+	if cfg.PprofAddr != "" {
+		go func() {
+			log.Printf("pprof listening on %s", cfg.PprofAddr)
+			if err := http.ListenAndServe(cfg.PprofAddr, nil); err != nil {
+				log.Printf("pprof server exited: %v", err)
+			}
+		}()
+	}
+
+	if cfg.CPUProfilePath != "" {
+		f, err := os.Create(cfg.CPUProfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("create cpu profile: %w", err)
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("start cpu profile: %w", err)
+		}
+		closers = append(closers, func() error {
+			pprof.StopCPUProfile()
+			return f.Close()
+		})
+	}
+
+	if cfg.BlockProfilePath != "" {
+		runtime.SetBlockProfileRate(1)
+		closers = append(closers, func() error { return writeProfile("block", cfg.BlockProfilePath) })
+	}
+
+	if cfg.MutexProfilePath != "" {
+		runtime.SetMutexProfileFraction(1)
+		closers = append(closers, func() error { return writeProfile("mutex", cfg.MutexProfilePath) })
+	}
+
+	if cfg.TracePath != "" {
+		f, err := os.Create(cfg.TracePath)
+		if err != nil {
+			return nil, fmt.Errorf("create trace: %w", err)
+		}
+		if err := trace.Start(f); err != nil {
+			f.Close()
+			return nil, fmt.Errorf("start trace: %w", err)
+		}
+		closers = append(closers, func() error {
+			trace.Stop()
+			return f.Close()
+		})
+	}
+
+	if cfg.MemProfilePath != "" {
+		closers = append(closers, func() error {
+			runtime.GC()
+			return writeProfile("allocs", cfg.MemProfilePath)
+		})
+	}
+
+	return func() error {
+		var firstErr error
+		for i := len(closers) - 1; i >= 0; i-- {
+			if err := closers[i](); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}, nil
+}
+
+func writeProfile(name, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create %s profile: %w", name, err)
+	}
+	defer f.Close()
+
+	p := pprof.Lookup(name)
+	if p == nil {
+		return fmt.Errorf("no registered %s profile", name)
+	}
+	if err := p.WriteTo(f, 0); err != nil {
+		return fmt.Errorf("write %s profile: %w", name, err)
+	}
+	return nil
+}
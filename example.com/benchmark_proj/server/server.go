@@ -0,0 +1,148 @@
+// Package server implements the REST and gRPC endpoints benchmarked by
+// this module, and knows how to start them standalone or under the
+// control of a benchmark-driver.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/netshape"
+
+	"google.golang.org/grpc"
+)
+
+// Data is the REST counterpart of benchmark.Data, used so the REST path
+// exercises ordinary encoding/json rather than protobuf's JSON mapping.
+type Data struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// restHandler implements the same "increment value" behavior as the
+// gRPC SendData RPC, for a REST-vs-gRPC comparison on equal footing.
+func restHandler(w http.ResponseWriter, r *http.Request) {
+	var data Data
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		http.Error(w, "Invalid request", http.StatusBadRequest)
+		return
+	}
+	data.Value += 1 // Simulate processing
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+// rawHandler echoes the request body back unchanged with the same
+// Content-Type, letting the REST transport be benchmarked against
+// arbitrary codecs without a handler per codec.
+func rawHandler(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", r.Header.Get("Content-Type"))
+	_, _ = w.Write(body)
+}
+
+// rpcService implements benchmark.APIServer.
+type rpcService struct {
+	benchmark.UnimplementedAPIServer
+}
+
+func (s *rpcService) SendData(ctx context.Context, req *benchmark.Data) (*benchmark.Data, error) {
+	req.Value += 1 // Simulate processing
+	return req, nil
+}
+
+// SendRaw echoes an arbitrarily-encoded payload back unchanged, so a
+// client can compare codecs over the gRPC transport without a dedicated
+// RPC per codec.
+func (s *rpcService) SendRaw(ctx context.Context, req *benchmark.RawEnvelope) (*benchmark.RawEnvelope, error) {
+	return req, nil
+}
+
+// StreamData echoes each incoming message back with Value incremented,
+// preserving Seq so the client can match responses to requests.
+func (s *rpcService) StreamData(stream benchmark.API_StreamDataServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		req.Value += 1 // Simulate processing
+		if err := stream.Send(req); err != nil {
+			return err
+		}
+	}
+}
+
+// Config is the address pair a server worker listens on.
+type Config struct {
+	RESTAddr string
+	GRPCAddr string
+
+	// NetShape, if non-zero, simulates network conditions (latency,
+	// jitter, bandwidth cap) on both listeners so payload-size effects
+	// show up the way they would on a real link instead of localhost.
+	NetShape netshape.Config
+}
+
+// Run starts the REST handler and gRPC service on the addresses in cfg
+// and blocks until ctx is canceled. It mirrors this module's original
+// single-binary server startup, now reusable by both the standalone
+// benchmark-server command and a driver-controlled worker.
+func Run(ctx context.Context, cfg Config) error {
+	restListener, err := net.Listen("tcp", cfg.RESTAddr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", cfg.RESTAddr, err)
+	}
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		return fmt.Errorf("listen on %s: %w", cfg.GRPCAddr, err)
+	}
+	if cfg.NetShape != (netshape.Config{}) {
+		restListener = netshape.NewListener(restListener, cfg.NetShape)
+		grpcListener = netshape.NewListener(grpcListener, cfg.NetShape)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api", restHandler)
+	mux.HandleFunc("/api/raw", rawHandler)
+	restServer := &http.Server{Handler: mux}
+	grpcServer := grpc.NewServer()
+	benchmark.RegisterAPIServer(grpcServer, &rpcService{})
+
+	errCh := make(chan error, 2)
+	go func() {
+		if err := restServer.Serve(restListener); err != nil && err != http.ErrServerClosed {
+			errCh <- fmt.Errorf("rest server: %w", err)
+		}
+	}()
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			errCh <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		grpcServer.GracefulStop()
+		if err := restServer.Close(); err != nil {
+			log.Printf("error closing REST server: %v", err)
+		}
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	}
+}
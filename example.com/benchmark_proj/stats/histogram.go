@@ -0,0 +1,264 @@
+// Package stats provides an HDR-style latency histogram used to turn raw
+// per-call samples into percentile reports (p50/p90/p95/p99/p99.9/max)
+// without keeping every sample in memory.
+package stats
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+const (
+	// sigDigits is the number of significant decimal digits of precision
+	// kept within each power-of-two bucket range. 2 digits gives <=1%
+	// relative error, which is what HdrHistogram-style tools default to.
+	sigDigits = 2
+
+	// minValue/maxValue bound the range of durations we bucket, from
+	// microseconds up to minutes. Samples outside this range are clamped
+	// into the nearest edge bucket rather than dropped.
+	minValue = int64(time.Microsecond)
+	maxValue = int64(10 * time.Minute)
+)
+
+// Histogram accumulates time.Duration samples into exponentially growing
+// buckets (floor(log2(d)) selects the bucket's exponent, then a linear
+// sub-bucket of size 2^(exponent-sigDigits) refines it), so it can report
+// accurate percentiles in O(1) space regardless of sample count.
+//
+// A Histogram is not safe for concurrent use; callers recording from
+// multiple goroutines should keep one Histogram per goroutine and Merge
+// them together once all writers are done.
+type Histogram struct {
+	subBucketBits int
+	buckets       []uint64
+	minExponent   int
+	maxExponent   int
+
+	count    uint64
+	min      int64
+	max      int64
+	sum      int64
+	sumSq    float64 // sum of squares, for stddev
+	errCount uint64
+}
+
+// NewHistogram returns an empty Histogram covering minValue..maxValue with
+// sigDigits of precision.
+func NewHistogram() *Histogram {
+	minExp := exponentOf(minValue)
+	maxExp := exponentOf(maxValue)
+	subBucketBits := sigDigits
+	bucketsPerExponent := 1 << subBucketBits
+
+	h := &Histogram{
+		subBucketBits: subBucketBits,
+		minExponent:   minExp,
+		maxExponent:   maxExp,
+		buckets:       make([]uint64, (maxExp-minExp+1)*bucketsPerExponent),
+		min:           math.MaxInt64,
+		max:           0,
+	}
+	return h
+}
+
+func exponentOf(v int64) int {
+	if v < 1 {
+		return 0
+	}
+	return int(math.Floor(math.Log2(float64(v))))
+}
+
+// index maps a duration to a flat bucket index, clamping to the
+// histogram's configured range.
+func (h *Histogram) index(d int64) int {
+	if d < minValue {
+		d = minValue
+	}
+	if d > maxValue {
+		d = maxValue
+	}
+	exp := exponentOf(d)
+	if exp < h.minExponent {
+		exp = h.minExponent
+	}
+	if exp > h.maxExponent {
+		exp = h.maxExponent
+	}
+
+	subBucketSize := int64(1) << uint(max(exp-h.subBucketBits, 0))
+	base := int64(1) << uint(exp)
+	sub := (d - base) / subBucketSize
+	bucketsPerExponent := int64(1) << uint(h.subBucketBits)
+	if sub >= bucketsPerExponent {
+		sub = bucketsPerExponent - 1
+	}
+
+	return (exp-h.minExponent)*int(bucketsPerExponent) + int(sub)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// Record adds a single latency sample to the histogram.
+func (h *Histogram) Record(d time.Duration) {
+	v := int64(d)
+	h.buckets[h.index(v)]++
+	h.count++
+	h.sum += v
+	h.sumSq += float64(v) * float64(v)
+	if v < h.min {
+		h.min = v
+	}
+	if v > h.max {
+		h.max = v
+	}
+}
+
+// RecordError counts a failed call separately from latency samples so
+// that errors no longer silently bias the mean and percentiles.
+func (h *Histogram) RecordError() {
+	h.errCount++
+}
+
+// Merge folds another histogram's counts into h. Both histograms must
+// have been created with NewHistogram (i.e. share the same bucket
+// layout), which holds for every Histogram produced by this package.
+func (h *Histogram) Merge(o *Histogram) {
+	for i, c := range o.buckets {
+		h.buckets[i] += c
+	}
+	h.count += o.count
+	h.errCount += o.errCount
+	h.sum += o.sum
+	h.sumSq += o.sumSq
+	if o.min < h.min {
+		h.min = o.min
+	}
+	if o.max > h.max {
+		h.max = o.max
+	}
+}
+
+// Count returns the number of successfully recorded latency samples.
+func (h *Histogram) Count() uint64 { return h.count }
+
+// ErrorCount returns the number of calls recorded via RecordError.
+func (h *Histogram) ErrorCount() uint64 { return h.errCount }
+
+// Min returns the smallest recorded sample.
+func (h *Histogram) Min() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return time.Duration(h.min)
+}
+
+// Max returns the largest recorded sample.
+func (h *Histogram) Max() time.Duration { return time.Duration(h.max) }
+
+// Mean returns the arithmetic mean of all recorded samples.
+func (h *Histogram) Mean() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	return time.Duration(h.sum / int64(h.count))
+}
+
+// StdDev returns the population standard deviation of recorded samples.
+func (h *Histogram) StdDev() time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	n := float64(h.count)
+	mean := float64(h.sum) / n
+	variance := h.sumSq/n - mean*mean
+	if variance < 0 {
+		variance = 0
+	}
+	return time.Duration(math.Sqrt(variance))
+}
+
+// Percentile returns the latency at the given percentile (0..100) by
+// walking cumulative bucket counts. p must be in [0, 100].
+func (h *Histogram) Percentile(p float64) time.Duration {
+	if h.count == 0 {
+		return 0
+	}
+	target := uint64(math.Ceil(p / 100 * float64(h.count)))
+	if target == 0 {
+		target = 1
+	}
+
+	var cumulative uint64
+	bucketsPerExponent := 1 << h.subBucketBits
+	for i, c := range h.buckets {
+		cumulative += c
+		if cumulative >= target {
+			exp := h.minExponent + i/bucketsPerExponent
+			sub := i % bucketsPerExponent
+			subBucketSize := int64(1) << uint(max(exp-h.subBucketBits, 0))
+			base := int64(1) << uint(exp)
+			return time.Duration(base + int64(sub)*subBucketSize)
+		}
+	}
+	return time.Duration(h.max)
+}
+
+// Snapshot is the set of derived statistics reported after a benchmark
+// run, suitable for printing or encoding as JSON/CSV.
+type Snapshot struct {
+	Count  uint64        `json:"count"`
+	Errors uint64        `json:"errors"`
+	Min    time.Duration `json:"min"`
+	Mean   time.Duration `json:"mean"`
+	StdDev time.Duration `json:"stddev"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P95    time.Duration `json:"p95"`
+	P99    time.Duration `json:"p99"`
+	P999   time.Duration `json:"p999"`
+	Max    time.Duration `json:"max"`
+	QPS    float64       `json:"qps"`
+}
+
+// Snapshot computes a Snapshot from the histogram's current contents.
+// elapsed is the wall-clock duration the samples were collected over,
+// used to derive QPS.
+func (h *Histogram) Snapshot(elapsed time.Duration) Snapshot {
+	s := Snapshot{
+		Count:  h.count,
+		Errors: h.errCount,
+		Min:    h.Min(),
+		Mean:   h.Mean(),
+		StdDev: h.StdDev(),
+		P50:    h.Percentile(50),
+		P90:    h.Percentile(90),
+		P95:    h.Percentile(95),
+		P99:    h.Percentile(99),
+		P999:   h.Percentile(99.9),
+		Max:    h.Max(),
+	}
+	if elapsed > 0 {
+		s.QPS = float64(h.count) / elapsed.Seconds()
+	}
+	return s
+}
+
+// String renders the snapshot as the multi-line human-readable report
+// printed to stdout after each benchmark.
+func (s Snapshot) String() string {
+	return fmt.Sprintf(
+		"  count=%d errors=%d qps=%.1f\n"+
+			"  min=%v mean=%v stddev=%v max=%v\n"+
+			"  p50=%v p90=%v p95=%v p99=%v p99.9=%v",
+		s.Count, s.Errors, s.QPS,
+		s.Min, s.Mean, s.StdDev, s.Max,
+		s.P50, s.P90, s.P95, s.P99, s.P999,
+	)
+}
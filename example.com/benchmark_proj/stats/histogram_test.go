@@ -0,0 +1,86 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHistogramPercentiles(t *testing.T) {
+	h := NewHistogram()
+	for i := 1; i <= 100; i++ {
+		h.Record(time.Duration(i) * time.Millisecond)
+	}
+
+	cases := []struct {
+		percentile float64
+		want       time.Duration
+	}{
+		{50, 50 * time.Millisecond},
+		{90, 90 * time.Millisecond},
+		{99, 99 * time.Millisecond},
+		{100, 100 * time.Millisecond},
+	}
+	for _, c := range cases {
+		// Sub-bucket quantization means the returned value isn't exact,
+		// so allow a generous tolerance - this is a regression check
+		// against gross bucket-index errors, not a precision test.
+		got := h.Percentile(c.percentile)
+		tolerance := c.want * 3 / 10
+		if diff := got - c.want; diff < -tolerance || diff > tolerance {
+			t.Errorf("Percentile(%v) = %v, want ~%v (tolerance %v)", c.percentile, got, c.want, tolerance)
+		}
+	}
+}
+
+func TestHistogramEmpty(t *testing.T) {
+	h := NewHistogram()
+	if got := h.Percentile(50); got != 0 {
+		t.Errorf("Percentile(50) on empty histogram = %v, want 0", got)
+	}
+	if got := h.Mean(); got != 0 {
+		t.Errorf("Mean() on empty histogram = %v, want 0", got)
+	}
+	if got := h.Min(); got != 0 {
+		t.Errorf("Min() on empty histogram = %v, want 0", got)
+	}
+}
+
+// TestHistogramClampsOutOfRangeSamples exercises index()'s clamping of
+// samples below minValue/above maxValue into the nearest edge bucket,
+// rather than panicking or corrupting later percentile queries.
+func TestHistogramClampsOutOfRangeSamples(t *testing.T) {
+	h := NewHistogram()
+	h.Record(0)         // below minValue
+	h.Record(time.Hour) // above maxValue
+	if h.Count() != 2 {
+		t.Fatalf("Count() = %d, want 2", h.Count())
+	}
+	if p := h.Percentile(100); p <= 0 {
+		t.Errorf("Percentile(100) = %v, want > 0", p)
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	a := NewHistogram()
+	b := NewHistogram()
+	a.Record(10 * time.Millisecond)
+	a.RecordError()
+	b.Record(20 * time.Millisecond)
+
+	merged := NewHistogram()
+	merged.Merge(a)
+	merged.Merge(b)
+
+	if merged.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", merged.Count())
+	}
+	if merged.ErrorCount() != 1 {
+		t.Errorf("ErrorCount() = %d, want 1", merged.ErrorCount())
+	}
+	if merged.Min() != 10*time.Millisecond {
+		t.Errorf("Min() = %v, want 10ms", merged.Min())
+	}
+	if merged.Max() != 20*time.Millisecond {
+		t.Errorf("Max() = %v, want 20ms", merged.Max())
+	}
+}
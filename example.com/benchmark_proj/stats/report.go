@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Report is a named collection of snapshots (e.g. one per benchmarked
+// API) that can be written out as machine-readable JSON or CSV so runs
+// can be diffed against each other.
+type Report struct {
+	Runs map[string]Snapshot `json:"runs"`
+}
+
+// NewReport returns an empty Report.
+func NewReport() *Report {
+	return &Report{Runs: make(map[string]Snapshot)}
+}
+
+// Add records the snapshot for a named run, e.g. "grpc" or "rest".
+func (r *Report) Add(name string, s Snapshot) {
+	r.Runs[name] = s
+}
+
+// WriteJSON writes the report to path as indented JSON.
+func (r *Report) WriteJSON(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create json report: %w", err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(r); err != nil {
+		return fmt.Errorf("encode json report: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes the report to path as a CSV table, one row per named
+// run, so results can be loaded into a spreadsheet or diffed with
+// standard tools.
+func (r *Report) WriteCSV(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("create csv report: %w", err)
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	defer w.Flush()
+
+	header := []string{"name", "count", "errors", "qps", "min", "mean", "stddev", "p50", "p90", "p95", "p99", "p99.9", "max"}
+	if err := w.Write(header); err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	names := make([]string, 0, len(r.Runs))
+	for name := range r.Runs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := r.Runs[name]
+		row := []string{
+			name,
+			fmt.Sprint(s.Count),
+			fmt.Sprint(s.Errors),
+			fmt.Sprintf("%.2f", s.QPS),
+			s.Min.String(),
+			s.Mean.String(),
+			s.StdDev.String(),
+			s.P50.String(),
+			s.P90.String(),
+			s.P95.String(),
+			s.P99.String(),
+			s.P999.String(),
+			s.Max.String(),
+		}
+		if err := w.Write(row); err != nil {
+			return fmt.Errorf("write csv row %q: %w", name, err)
+		}
+	}
+	return nil
+}
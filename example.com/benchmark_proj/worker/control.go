@@ -0,0 +1,199 @@
+// Package worker implements the WorkerControl service (see worker.proto)
+// that lets a benchmark-driver remotely configure benchmark-server and
+// benchmark-client processes and collect their stats as they run.
+package worker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"example.com/benchmark_proj/benchmark"
+	"example.com/benchmark_proj/client"
+	"example.com/benchmark_proj/loadgen"
+	"example.com/benchmark_proj/netshape"
+	"example.com/benchmark_proj/server"
+)
+
+// statsInterval is how often a running RunServer/RunClient call streams
+// a StatsUpdate back to the driver.
+const statsInterval = 5 * time.Second
+
+// ControlServer implements the generated WorkerControlServer interface,
+// adapting this module's existing server/client packages to the
+// driver's control protocol.
+type ControlServer struct {
+	UnimplementedWorkerControlServer
+
+	// ID identifies this worker in StatsUpdate messages, e.g. its host:port.
+	ID string
+}
+
+// NewControlServer returns a ControlServer identified by id.
+func NewControlServer(id string) *ControlServer {
+	return &ControlServer{ID: id}
+}
+
+// RunServer starts serving REST+gRPC traffic per cfg, shaped by cfg's
+// network-simulation fields if set, and streams placeholder stats
+// updates (server-side request counts are reported by the client
+// driving load against it) until the driver cancels the stream.
+func (c *ControlServer) RunServer(cfg *ServerConfig, stream WorkerControl_RunServerServer) error {
+	ctx := stream.Context()
+	shape := netshape.Config{
+		Latency:              time.Duration(cfg.LatencyNanos),
+		Jitter:               time.Duration(cfg.JitterNanos),
+		BandwidthBytesPerSec: cfg.BandwidthBytesPerSec,
+		MTU:                  int(cfg.Mtu),
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Run(ctx, server.Config{RESTAddr: cfg.RestAddr, GRPCAddr: cfg.GrpcAddr, NetShape: shape})
+	}()
+
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errCh:
+			return err
+		case <-ticker.C:
+			if err := stream.Send(&StatsUpdate{WorkerId: c.ID}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// RunClient dials cfg.ServerAddr, drives cfg.Concurrency callers against
+// it per cfg.RpcType/cfg.LoadType (warming up for cfg.WarmupSeconds, then
+// measuring for cfg.MeasureSeconds), and streams real periodic
+// StatsUpdate snapshots of the in-progress run until the measure window
+// elapses or the driver cancels.
+func (c *ControlServer) RunClient(cfg *ClientConfig, stream WorkerControl_RunClientServer) error {
+	ctx := stream.Context()
+
+	conn, err := client.Dial(cfg.ServerAddr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	apiClient := benchmark.NewAPIClient(conn)
+	data := clientData(c.ID, cfg.PayloadSize)
+
+	if warmup := time.Duration(cfg.WarmupSeconds) * time.Second; warmup > 0 {
+		warmupCtx, cancel := context.WithTimeout(ctx, warmup)
+		runClientLoad(warmupCtx, apiClient, data, cfg, client.NewLiveHistogram())
+		cancel()
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+
+	measureCtx, cancel := context.WithTimeout(ctx, time.Duration(cfg.MeasureSeconds)*time.Second)
+	defer cancel()
+
+	hist := client.NewLiveHistogram()
+	loadDone := make(chan struct{})
+	go func() {
+		defer close(loadDone)
+		runClientLoad(measureCtx, apiClient, data, cfg, hist)
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(statsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-loadDone:
+			return stream.Send(statsUpdate(c.ID, hist, time.Since(start)))
+		case <-ticker.C:
+			if err := stream.Send(statsUpdate(c.ID, hist, time.Since(start))); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// clientData builds the gRPC payload a client worker sends, padding Name
+// out to payloadSize bytes so cfg.PayloadSize actually affects wire
+// size the way it does for the standalone benchmark-client.
+func clientData(workerID string, payloadSize int32) *benchmark.Data {
+	if payloadSize <= 0 {
+		payloadSize = 1
+	}
+	return &benchmark.Data{Id: workerID, Name: strings.Repeat("x", int(payloadSize))}
+}
+
+// runClientLoad dispatches to the live-reporting load driver matching
+// cfg.RpcType/cfg.LoadType, recording every call into hist until ctx is
+// done.
+func runClientLoad(ctx context.Context, apiClient benchmark.APIClient, data *benchmark.Data, cfg *ClientConfig, hist *client.LiveHistogram) {
+	if cfg.RpcType == RPCType_STREAMING {
+		client.RunStreamingRPC(ctx, apiClient, client.StreamConfig{
+			Streams:           int(cfg.Concurrency),
+			MessagesPerStream: int(cfg.StreamMessages),
+			Pacing:            streamPacing(cfg),
+			InFlightWindow:    int(cfg.InFlightWindow),
+		}, hist)
+		return
+	}
+
+	switch cfg.LoadType {
+	case LoadType_OPEN_POISSON, LoadType_OPEN_CONSTANT:
+		client.RunOpenLoopRPC(ctx, apiClient, data, client.OpenLoopConfig{
+			LoadType:    openLoadType(cfg.LoadType),
+			TargetQPS:   cfg.TargetQps,
+			Duration:    durationUntilDeadline(ctx),
+			Concurrency: int(cfg.Concurrency),
+		}, hist)
+	default:
+		client.RunClosedLoopRPC(ctx, apiClient, data, int(cfg.Concurrency), hist)
+	}
+}
+
+func streamPacing(cfg *ClientConfig) client.StreamPacing {
+	if cfg.StreamPipelined {
+		return client.Pipelined
+	}
+	return client.PingPong
+}
+
+func openLoadType(lt LoadType) loadgen.LoadType {
+	if lt == LoadType_OPEN_POISSON {
+		return loadgen.OpenPoisson
+	}
+	return loadgen.OpenConstant
+}
+
+// durationUntilDeadline returns how long is left until ctx's deadline,
+// which is how RunClient bounds a phase's open-loop schedule (loadgen
+// itself paces by duration, not by ctx).
+func durationUntilDeadline(ctx context.Context) time.Duration {
+	if deadline, ok := ctx.Deadline(); ok {
+		return time.Until(deadline)
+	}
+	return 0
+}
+
+// statsUpdate converts hist's current contents into a StatsUpdate for
+// the driver.
+func statsUpdate(workerID string, hist *client.LiveHistogram, elapsed time.Duration) *StatsUpdate {
+	s := hist.Snapshot(elapsed)
+	return &StatsUpdate{
+		WorkerId:  workerID,
+		Count:     s.Count,
+		Errors:    s.Errors,
+		Qps:       s.QPS,
+		P50Nanos:  s.P50.Nanoseconds(),
+		P90Nanos:  s.P90.Nanoseconds(),
+		P95Nanos:  s.P95.Nanoseconds(),
+		P99Nanos:  s.P99.Nanoseconds(),
+		P999Nanos: s.P999.Nanoseconds(),
+		MaxNanos:  s.Max.Nanoseconds(),
+	}
+}